@@ -0,0 +1,56 @@
+package kube_inventory
+
+import (
+	"time"
+
+	"github.com/influxdata/telegraf"
+)
+
+// clusterAccumulator wraps a telegraf.Accumulator and stamps every metric it
+// emits with the "cluster" tag, so a single plugin instance gathering from
+// several clusters produces metrics an operator can tell apart.
+type clusterAccumulator struct {
+	telegraf.Accumulator
+	clusterName string
+}
+
+func (a *clusterAccumulator) withClusterTag(tags map[string]string) map[string]string {
+	if a.clusterName == "" {
+		return tags
+	}
+
+	tagged := make(map[string]string, len(tags)+1)
+	for k, v := range tags {
+		tagged[k] = v
+	}
+	tagged["cluster"] = a.clusterName
+
+	return tagged
+}
+
+func (a *clusterAccumulator) AddFields(measurement string, fields map[string]interface{}, tags map[string]string, t ...time.Time) {
+	a.Accumulator.AddFields(measurement, fields, a.withClusterTag(tags), t...)
+}
+
+func (a *clusterAccumulator) AddGauge(measurement string, fields map[string]interface{}, tags map[string]string, t ...time.Time) {
+	a.Accumulator.AddGauge(measurement, fields, a.withClusterTag(tags), t...)
+}
+
+func (a *clusterAccumulator) AddCounter(measurement string, fields map[string]interface{}, tags map[string]string, t ...time.Time) {
+	a.Accumulator.AddCounter(measurement, fields, a.withClusterTag(tags), t...)
+}
+
+func (a *clusterAccumulator) AddSummary(measurement string, fields map[string]interface{}, tags map[string]string, t ...time.Time) {
+	a.Accumulator.AddSummary(measurement, fields, a.withClusterTag(tags), t...)
+}
+
+func (a *clusterAccumulator) AddHistogram(measurement string, fields map[string]interface{}, tags map[string]string, t ...time.Time) {
+	a.Accumulator.AddHistogram(measurement, fields, a.withClusterTag(tags), t...)
+}
+
+func (a *clusterAccumulator) AddMetric(m telegraf.Metric) {
+	if a.clusterName != "" {
+		m.AddTag("cluster", a.clusterName)
+	}
+	a.Accumulator.AddMetric(m)
+}
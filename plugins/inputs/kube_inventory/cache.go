@@ -0,0 +1,135 @@
+package kube_inventory
+
+import (
+	applisters "k8s.io/client-go/listers/apps/v1"
+	autoscalinglisters "k8s.io/client-go/listers/autoscaling/v2"
+	batchlisters "k8s.io/client-go/listers/batch/v1"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	networkinglisters "k8s.io/client-go/listers/networking/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+// cachedResourceKinds lists the resource_include/resource_exclude names whose
+// collectors are cache-backed when watch = true. This is every resource kind
+// availableCollectors knows about: the original poll-based collectors need
+// watch's cache just as much as the ones added alongside it, since they're
+// the expensive, high-cardinality kinds (pods chief among them) watch mode
+// exists to stop hitting the apiserver for on every Gather.
+var cachedResourceKinds = []string{
+	"daemonsets",
+	"deployments",
+	"nodes",
+	"persistentvolumes",
+	"persistentvolumeclaims",
+	"pods",
+	"statefulsets",
+	"services",
+	"ingresses",
+	"jobs",
+	"cronjobs",
+	"hpas",
+	"resourcequotas",
+	"namespaces",
+}
+
+// registerCacheInformer registers the informer backing resource's Lister on
+// cr's shared informer factory and returns its HasSynced func, so Start can
+// wait for it to be populated before Gather starts reading from it.
+func registerCacheInformer(cr *clusterRuntime, resource string) cache.InformerSynced {
+	switch resource {
+	case "daemonsets":
+		return cr.informerFactory.Apps().V1().DaemonSets().Informer().HasSynced
+	case "deployments":
+		return cr.informerFactory.Apps().V1().Deployments().Informer().HasSynced
+	case "nodes":
+		return cr.informerFactory.Core().V1().Nodes().Informer().HasSynced
+	case "persistentvolumes":
+		return cr.informerFactory.Core().V1().PersistentVolumes().Informer().HasSynced
+	case "persistentvolumeclaims":
+		return cr.informerFactory.Core().V1().PersistentVolumeClaims().Informer().HasSynced
+	case "pods":
+		return cr.informerFactory.Core().V1().Pods().Informer().HasSynced
+	case "statefulsets":
+		return cr.informerFactory.Apps().V1().StatefulSets().Informer().HasSynced
+	case "services":
+		return cr.informerFactory.Core().V1().Services().Informer().HasSynced
+	case "ingresses":
+		return cr.informerFactory.Networking().V1().Ingresses().Informer().HasSynced
+	case "jobs":
+		return cr.informerFactory.Batch().V1().Jobs().Informer().HasSynced
+	case "cronjobs":
+		return cr.informerFactory.Batch().V1().CronJobs().Informer().HasSynced
+	case "hpas":
+		return cr.informerFactory.Autoscaling().V2().HorizontalPodAutoscalers().Informer().HasSynced
+	case "resourcequotas":
+		return cr.informerFactory.Core().V1().ResourceQuotas().Informer().HasSynced
+	case "namespaces":
+		return cr.informerFactory.Core().V1().Namespaces().Informer().HasSynced
+	default:
+		return nil
+	}
+}
+
+func (cr *clusterRuntime) daemonSetsLister() applisters.DaemonSetLister {
+	return cr.informerFactory.Apps().V1().DaemonSets().Lister()
+}
+
+func (cr *clusterRuntime) deploymentsLister() applisters.DeploymentLister {
+	return cr.informerFactory.Apps().V1().Deployments().Lister()
+}
+
+func (cr *clusterRuntime) nodesLister() corelisters.NodeLister {
+	return cr.informerFactory.Core().V1().Nodes().Lister()
+}
+
+func (cr *clusterRuntime) persistentVolumesLister() corelisters.PersistentVolumeLister {
+	return cr.informerFactory.Core().V1().PersistentVolumes().Lister()
+}
+
+func (cr *clusterRuntime) persistentVolumeClaimsLister() corelisters.PersistentVolumeClaimLister {
+	return cr.informerFactory.Core().V1().PersistentVolumeClaims().Lister()
+}
+
+func (cr *clusterRuntime) podsLister() corelisters.PodLister {
+	return cr.informerFactory.Core().V1().Pods().Lister()
+}
+
+func (cr *clusterRuntime) statefulSetsLister() applisters.StatefulSetLister {
+	return cr.informerFactory.Apps().V1().StatefulSets().Lister()
+}
+
+func (cr *clusterRuntime) servicesLister() corelisters.ServiceLister {
+	return cr.informerFactory.Core().V1().Services().Lister()
+}
+
+func (cr *clusterRuntime) endpointsLister() corelisters.EndpointsLister {
+	return cr.informerFactory.Core().V1().Endpoints().Lister()
+}
+
+func (cr *clusterRuntime) secretsLister() corelisters.SecretLister {
+	return cr.informerFactory.Core().V1().Secrets().Lister()
+}
+
+func (cr *clusterRuntime) ingressesLister() networkinglisters.IngressLister {
+	return cr.informerFactory.Networking().V1().Ingresses().Lister()
+}
+
+func (cr *clusterRuntime) jobsLister() batchlisters.JobLister {
+	return cr.informerFactory.Batch().V1().Jobs().Lister()
+}
+
+func (cr *clusterRuntime) cronJobsLister() batchlisters.CronJobLister {
+	return cr.informerFactory.Batch().V1().CronJobs().Lister()
+}
+
+func (cr *clusterRuntime) horizontalPodAutoscalersLister() autoscalinglisters.HorizontalPodAutoscalerLister {
+	return cr.informerFactory.Autoscaling().V2().HorizontalPodAutoscalers().Lister()
+}
+
+func (cr *clusterRuntime) resourceQuotasLister() corelisters.ResourceQuotaLister {
+	return cr.informerFactory.Core().V1().ResourceQuotas().Lister()
+}
+
+func (cr *clusterRuntime) namespacesLister() corelisters.NamespaceLister {
+	return cr.informerFactory.Core().V1().Namespaces().Lister()
+}
@@ -0,0 +1,126 @@
+package kube_inventory
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/influxdata/telegraf/internal/tls"
+
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"k8s.io/client-go/kubernetes"
+	restclient "k8s.io/client-go/rest"
+)
+
+type client struct {
+	namespace string
+	timeout   time.Duration
+	*kubernetes.Clientset
+}
+
+func newClient(namespace string, timeout time.Duration, cfg *restclient.Config) (*client, error) {
+	cfg.Timeout = timeout
+
+	clientset, err := kubernetes.NewForConfig(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("error creating kubernetes client: %v", err)
+	}
+
+	return &client{
+		namespace: namespace,
+		timeout:   timeout,
+		Clientset: clientset,
+	}, nil
+}
+
+// restConfigFromURL builds the rest.Config used to talk to the apiserver from
+// an explicit URL and bearer token. See config.go for the in-cluster and
+// kubeconfig alternatives used when url is left blank.
+func restConfigFromURL(baseURL, bearerToken string, tlsConfig tls.ClientConfig) (*restclient.Config, error) {
+	// Validate the TLS settings up front so a bad ca/cert/key is reported
+	// at startup rather than on the first request.
+	if _, err := tlsConfig.TLSConfig(); err != nil {
+		return nil, err
+	}
+
+	return &restclient.Config{
+		Host:        baseURL,
+		BearerToken: bearerToken,
+		TLSClientConfig: restclient.TLSClientConfig{
+			Insecure: tlsConfig.InsecureSkipVerify,
+			CAFile:   tlsConfig.TLSCA,
+			CertFile: tlsConfig.TLSCert,
+			KeyFile:  tlsConfig.TLSKey,
+		},
+	}, nil
+}
+
+func (c *client) getDaemonSets(ctx context.Context, opts metav1.ListOptions) (*appsv1.DaemonSetList, error) {
+	return c.AppsV1().DaemonSets(c.namespace).List(ctx, opts)
+}
+
+func (c *client) getDeployments(ctx context.Context, opts metav1.ListOptions) (*appsv1.DeploymentList, error) {
+	return c.AppsV1().Deployments(c.namespace).List(ctx, opts)
+}
+
+func (c *client) getNodes(ctx context.Context, opts metav1.ListOptions) (*corev1.NodeList, error) {
+	return c.CoreV1().Nodes().List(ctx, opts)
+}
+
+func (c *client) getPersistentVolumes(ctx context.Context, opts metav1.ListOptions) (*corev1.PersistentVolumeList, error) {
+	return c.CoreV1().PersistentVolumes().List(ctx, opts)
+}
+
+func (c *client) getPersistentVolumeClaims(ctx context.Context, opts metav1.ListOptions) (*corev1.PersistentVolumeClaimList, error) {
+	return c.CoreV1().PersistentVolumeClaims(c.namespace).List(ctx, opts)
+}
+
+func (c *client) getPods(ctx context.Context, opts metav1.ListOptions) (*corev1.PodList, error) {
+	return c.CoreV1().Pods(c.namespace).List(ctx, opts)
+}
+
+func (c *client) getStatefulSets(ctx context.Context, opts metav1.ListOptions) (*appsv1.StatefulSetList, error) {
+	return c.AppsV1().StatefulSets(c.namespace).List(ctx, opts)
+}
+
+func (c *client) getServices(ctx context.Context, opts metav1.ListOptions) (*corev1.ServiceList, error) {
+	return c.CoreV1().Services(c.namespace).List(ctx, opts)
+}
+
+func (c *client) getEndpoints(ctx context.Context, namespace, name string) (*corev1.Endpoints, error) {
+	return c.CoreV1().Endpoints(namespace).Get(ctx, name, metav1.GetOptions{})
+}
+
+func (c *client) getSecret(ctx context.Context, namespace, name string) (*corev1.Secret, error) {
+	return c.CoreV1().Secrets(namespace).Get(ctx, name, metav1.GetOptions{})
+}
+
+func (c *client) getIngresses(ctx context.Context, opts metav1.ListOptions) (*networkingv1.IngressList, error) {
+	return c.NetworkingV1().Ingresses(c.namespace).List(ctx, opts)
+}
+
+func (c *client) getJobs(ctx context.Context, opts metav1.ListOptions) (*batchv1.JobList, error) {
+	return c.BatchV1().Jobs(c.namespace).List(ctx, opts)
+}
+
+func (c *client) getCronJobs(ctx context.Context, opts metav1.ListOptions) (*batchv1.CronJobList, error) {
+	return c.BatchV1().CronJobs(c.namespace).List(ctx, opts)
+}
+
+func (c *client) getHorizontalPodAutoscalers(ctx context.Context, opts metav1.ListOptions) (*autoscalingv2.HorizontalPodAutoscalerList, error) {
+	return c.AutoscalingV2().HorizontalPodAutoscalers(c.namespace).List(ctx, opts)
+}
+
+func (c *client) getResourceQuotas(ctx context.Context, opts metav1.ListOptions) (*corev1.ResourceQuotaList, error) {
+	return c.CoreV1().ResourceQuotas(c.namespace).List(ctx, opts)
+}
+
+func (c *client) getNamespaces(ctx context.Context, opts metav1.ListOptions) (*corev1.NamespaceList, error) {
+	return c.CoreV1().Namespaces().List(ctx, opts)
+}
@@ -0,0 +1,105 @@
+package kube_inventory
+
+import (
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"github.com/influxdata/telegraf/internal/tls"
+
+	"k8s.io/client-go/informers"
+)
+
+// ClusterConfig describes one Kubernetes cluster to gather from. Setting
+// url/bearer_token/namespace/tls_* directly on the plugin is still supported
+// and is equivalent to a single entry in clusters.
+type ClusterConfig struct {
+	URL               string `toml:"url"`
+	BearerToken       string `toml:"bearer_token"`
+	BearerTokenString string `toml:"bearer_token_string"`
+	Namespace         string `toml:"namespace"`
+	ClusterName       string `toml:"cluster_name"`
+	Kubeconfig        string `toml:"kubeconfig"`
+	KubeconfigContext string `toml:"kubeconfig_context"`
+
+	tls.ClientConfig
+}
+
+// clusterRuntime holds the per-cluster state built from a ClusterConfig:
+// the apiserver client and, when watch = true, its informer factory.
+type clusterRuntime struct {
+	cfg    ClusterConfig
+	client *client
+
+	informerFactory informers.SharedInformerFactory
+	stopCh          chan struct{}
+}
+
+// clusterConfigs returns the configured clusters, falling back to a single
+// entry built from the top-level url/bearer_token/namespace/tls_* settings
+// for backward compatibility with single-cluster configs.
+func (ki *KubernetesInventory) clusterConfigs() []ClusterConfig {
+	if len(ki.Clusters) > 0 {
+		cfgs := make([]ClusterConfig, len(ki.Clusters))
+		copy(cfgs, ki.Clusters)
+		return cfgs
+	}
+
+	return []ClusterConfig{
+		{
+			URL:               ki.URL,
+			BearerToken:       ki.BearerToken,
+			BearerTokenString: ki.BearerTokenString,
+			Namespace:         ki.Namespace,
+			ClusterName:       ki.ClusterName,
+			Kubeconfig:        ki.Kubeconfig,
+			KubeconfigContext: ki.KubeconfigContext,
+			ClientConfig:      ki.ClientConfig,
+		},
+	}
+}
+
+// ensureDistinctClusterNames fills in a ClusterName for any entry that left
+// it blank, when there's more than one cluster to tell apart. cluster_name is
+// documented as optional, but clusterAccumulator only tags metrics with it
+// when it's set; leaving it blank on two or more entries would otherwise
+// collapse their metrics into the same series. Falls back to the cluster's
+// url, or its kubeconfig path (plus context, so two contexts of the same
+// kubeconfig don't collapse into each other) when url is also blank.
+// cfgs is expected to be a copy of the user's config, not ki.Clusters itself.
+func ensureDistinctClusterNames(cfgs []ClusterConfig) {
+	if len(cfgs) < 2 {
+		return
+	}
+
+	for i := range cfgs {
+		if cfgs[i].ClusterName != "" {
+			continue
+		}
+
+		switch {
+		case cfgs[i].URL != "":
+			cfgs[i].ClusterName = cfgs[i].URL
+		case cfgs[i].Kubeconfig != "" && cfgs[i].KubeconfigContext != "":
+			cfgs[i].ClusterName = cfgs[i].Kubeconfig + "#" + cfgs[i].KubeconfigContext
+		case cfgs[i].Kubeconfig != "":
+			cfgs[i].ClusterName = cfgs[i].Kubeconfig
+		default:
+			cfgs[i].ClusterName = fmt.Sprintf("cluster-%d", i)
+		}
+	}
+}
+
+func (cfg *ClusterConfig) resolveBearerToken() error {
+	if cfg.BearerToken == "" {
+		return nil
+	}
+
+	token, err := ioutil.ReadFile(cfg.BearerToken)
+	if err != nil {
+		return err
+	}
+	cfg.BearerTokenString = strings.TrimSpace(string(token))
+
+	return nil
+}
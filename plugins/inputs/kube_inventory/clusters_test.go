@@ -0,0 +1,127 @@
+package kube_inventory
+
+import (
+	"testing"
+
+	"github.com/influxdata/telegraf/testutil"
+)
+
+func TestClusterConfigsFallsBackToTopLevelSettings(t *testing.T) {
+	ki := &KubernetesInventory{URL: "https://127.0.0.1", Namespace: "default", ClusterName: "solo"}
+
+	cfgs := ki.clusterConfigs()
+
+	if len(cfgs) != 1 {
+		t.Fatalf("expected a single cluster config, got %d", len(cfgs))
+	}
+	if cfgs[0].URL != "https://127.0.0.1" || cfgs[0].ClusterName != "solo" {
+		t.Fatalf("expected the top-level url/cluster_name to carry over, got %+v", cfgs[0])
+	}
+}
+
+func TestClusterConfigsUsesClustersWhenSet(t *testing.T) {
+	ki := &KubernetesInventory{
+		URL: "https://ignored",
+		Clusters: []ClusterConfig{
+			{URL: "https://cluster-a", ClusterName: "a"},
+			{URL: "https://cluster-b", ClusterName: "b"},
+		},
+	}
+
+	cfgs := ki.clusterConfigs()
+
+	if len(cfgs) != 2 {
+		t.Fatalf("expected clusters to override the top-level single-cluster form, got %+v", cfgs)
+	}
+}
+
+func TestEnsureDistinctClusterNamesLeavesSingleClusterAlone(t *testing.T) {
+	cfgs := []ClusterConfig{{URL: "https://only-one"}}
+
+	ensureDistinctClusterNames(cfgs)
+
+	if cfgs[0].ClusterName != "" {
+		t.Fatalf("expected a single cluster's blank cluster_name to stay blank, got %q", cfgs[0].ClusterName)
+	}
+}
+
+func TestEnsureDistinctClusterNamesFallsBackToURL(t *testing.T) {
+	cfgs := []ClusterConfig{
+		{URL: "https://cluster-a", ClusterName: "a"},
+		{URL: "https://cluster-b"},
+	}
+
+	ensureDistinctClusterNames(cfgs)
+
+	if cfgs[0].ClusterName != "a" {
+		t.Fatalf("expected an already-set cluster_name to be left alone, got %q", cfgs[0].ClusterName)
+	}
+	if cfgs[1].ClusterName != "https://cluster-b" {
+		t.Fatalf("expected the blank cluster_name to fall back to its url, got %q", cfgs[1].ClusterName)
+	}
+}
+
+func TestEnsureDistinctClusterNamesFallsBackToKubeconfigWhenURLBlank(t *testing.T) {
+	cfgs := []ClusterConfig{
+		{Kubeconfig: "/etc/kube/a.yaml"},
+		{Kubeconfig: "/etc/kube/b.yaml"},
+	}
+
+	ensureDistinctClusterNames(cfgs)
+
+	if cfgs[0].ClusterName != "/etc/kube/a.yaml" || cfgs[1].ClusterName != "/etc/kube/b.yaml" {
+		t.Fatalf("expected blank cluster_names to fall back to their kubeconfig path, got %+v", cfgs)
+	}
+}
+
+func TestEnsureDistinctClusterNamesDisambiguatesSharedKubeconfigByContext(t *testing.T) {
+	cfgs := []ClusterConfig{
+		{Kubeconfig: "/etc/kube/config", KubeconfigContext: "prod"},
+		{Kubeconfig: "/etc/kube/config", KubeconfigContext: "staging"},
+	}
+
+	ensureDistinctClusterNames(cfgs)
+
+	if cfgs[0].ClusterName == cfgs[1].ClusterName {
+		t.Fatalf("expected different contexts of the same kubeconfig to get distinct cluster_names, both got %q", cfgs[0].ClusterName)
+	}
+}
+
+func TestClusterConfigsReturnsACopyOfClusters(t *testing.T) {
+	ki := &KubernetesInventory{
+		Clusters: []ClusterConfig{{URL: "https://cluster-a"}, {URL: "https://cluster-b"}},
+	}
+
+	cfgs := ki.clusterConfigs()
+	ensureDistinctClusterNames(cfgs)
+
+	if ki.Clusters[0].ClusterName != "" || ki.Clusters[1].ClusterName != "" {
+		t.Fatalf("expected ensureDistinctClusterNames to leave ki.Clusters untouched, got %+v", ki.Clusters)
+	}
+}
+
+func TestClusterAccumulatorTagsMetricsWithClusterName(t *testing.T) {
+	acc := &testutil.Accumulator{}
+	clusterAcc := &clusterAccumulator{Accumulator: acc, clusterName: "cluster-a"}
+
+	clusterAcc.AddFields("kubernetes_node", map[string]interface{}{"created": int64(0)}, map[string]string{"node_name": "n1"})
+
+	acc.AssertContainsTaggedFields(t, "kubernetes_node", map[string]interface{}{"created": int64(0)}, map[string]string{
+		"node_name": "n1",
+		"cluster":   "cluster-a",
+	})
+}
+
+func TestClusterAccumulatorLeavesTagsAloneWithoutClusterName(t *testing.T) {
+	acc := &testutil.Accumulator{}
+	clusterAcc := &clusterAccumulator{Accumulator: acc}
+
+	clusterAcc.AddFields("kubernetes_node", map[string]interface{}{"created": int64(0)}, map[string]string{"node_name": "n1"})
+
+	acc.AssertContainsTaggedFields(t, "kubernetes_node", map[string]interface{}{"created": int64(0)}, map[string]string{
+		"node_name": "n1",
+	})
+	if len(acc.Metrics) != 1 || len(acc.Metrics[0].Tags) != 1 {
+		t.Fatalf("expected no cluster tag to be added when clusterName is empty, got %+v", acc.Metrics)
+	}
+}
@@ -0,0 +1,218 @@
+package kube_inventory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	restclient "k8s.io/client-go/rest"
+
+	"github.com/influxdata/telegraf/testutil"
+)
+
+func TestGatherJobDefaultsCompletionsAndParallelism(t *testing.T) {
+	ki := &KubernetesInventory{}
+	acc := &testutil.Accumulator{}
+
+	ki.gatherJob(&batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "backfill"},
+		Status:     batchv1.JobStatus{Active: 1, Succeeded: 2, Failed: 0},
+	}, acc)
+
+	acc.AssertContainsFields(t, jobMeasurement, map[string]interface{}{
+		"created":     metav1.Time{}.Unix(),
+		"active":      int32(1),
+		"succeeded":   int32(2),
+		"failed":      int32(0),
+		"completions": int32(1),
+		"parallelism": int32(1),
+	})
+}
+
+func TestGatherCronJobReportsLastScheduleTime(t *testing.T) {
+	ki := &KubernetesInventory{}
+	acc := &testutil.Accumulator{}
+	scheduled := metav1.NewTime(time.Unix(1700000000, 0))
+
+	ki.gatherCronJob(&batchv1.CronJob{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "nightly"},
+		Spec:       batchv1.CronJobSpec{Schedule: "0 0 * * *"},
+		Status:     batchv1.CronJobStatus{LastScheduleTime: &scheduled},
+	}, acc)
+
+	acc.AssertContainsTaggedFields(t, cronJobMeasurement, map[string]interface{}{
+		"created":            metav1.Time{}.Unix(),
+		"last_schedule_time": scheduled.Unix(),
+		"active":             0,
+		"suspend":            false,
+	}, map[string]string{
+		"namespace":    "default",
+		"cronjob_name": "nightly",
+		"schedule":     "0 0 * * *",
+	})
+}
+
+func TestGatherHorizontalPodAutoscalerReportsCurrentAndTargetUtilization(t *testing.T) {
+	ki := &KubernetesInventory{}
+	acc := &testutil.Accumulator{}
+
+	target := int32(80)
+	current := int32(55)
+	hpa := &autoscalingv2.HorizontalPodAutoscaler{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "api"},
+		Spec: autoscalingv2.HorizontalPodAutoscalerSpec{
+			MaxReplicas: 10,
+			Metrics: []autoscalingv2.MetricSpec{
+				{
+					Type: autoscalingv2.ResourceMetricSourceType,
+					Resource: &autoscalingv2.ResourceMetricSource{
+						Name:   corev1.ResourceCPU,
+						Target: autoscalingv2.MetricTarget{AverageUtilization: &target},
+					},
+				},
+			},
+		},
+		Status: autoscalingv2.HorizontalPodAutoscalerStatus{
+			CurrentReplicas: 3,
+			DesiredReplicas: 4,
+			CurrentMetrics: []autoscalingv2.MetricStatus{
+				{
+					Type: autoscalingv2.ResourceMetricSourceType,
+					Resource: &autoscalingv2.ResourceMetricStatus{
+						Name:    corev1.ResourceCPU,
+						Current: autoscalingv2.MetricValueStatus{AverageUtilization: &current},
+					},
+				},
+			},
+		},
+	}
+
+	ki.gatherHorizontalPodAutoscaler(hpa, acc)
+
+	acc.AssertContainsFields(t, hpaMeasurement+"_metric", map[string]interface{}{
+		"current_utilization": current,
+		"target_utilization":  target,
+	})
+}
+
+func TestGatherResourceQuotaReportsHardAndUsed(t *testing.T) {
+	ki := &KubernetesInventory{}
+	acc := &testutil.Accumulator{}
+
+	rq := &corev1.ResourceQuota{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "compute"},
+		Status: corev1.ResourceQuotaStatus{
+			Hard: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("4")},
+			Used: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1")},
+		},
+	}
+
+	ki.gatherResourceQuota(rq, acc)
+
+	acc.AssertContainsFields(t, resourceQuotaMeasurement, map[string]interface{}{
+		"hard": float64(4),
+		"used": float64(1),
+	})
+}
+
+func TestGatherNamespaceReportsPhase(t *testing.T) {
+	ki := &KubernetesInventory{}
+	acc := &testutil.Accumulator{}
+
+	created := metav1.NewTime(time.Unix(1700000000, 0))
+	ki.gatherNamespace(&corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{Name: "prod", CreationTimestamp: created},
+		Status:     corev1.NamespaceStatus{Phase: corev1.NamespaceActive},
+	}, acc)
+
+	acc.AssertContainsTaggedFields(t, namespaceMeasurement, map[string]interface{}{
+		"created": created.Unix(),
+	}, map[string]string{
+		"namespace_name": "prod",
+		"phase":          "Active",
+	})
+}
+
+// fakeClusterRuntime builds a clusterRuntime whose client talks to an
+// unreachable apiserver, so getEndpoints/getSecret fail fast instead of
+// blocking on a real cluster - enough to exercise gatherService/gatherIngress
+// end to end without one.
+func fakeClusterRuntime(t *testing.T) *clusterRuntime {
+	t.Helper()
+
+	c, err := newClient("default", 50*time.Millisecond, &restclient.Config{Host: "http://127.0.0.1:1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return &clusterRuntime{client: c}
+}
+
+func TestGatherServiceReportsPortCount(t *testing.T) {
+	ki := &KubernetesInventory{}
+	acc := &testutil.Accumulator{}
+	cr := fakeClusterRuntime(t)
+
+	svc := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "api"},
+		Spec: corev1.ServiceSpec{
+			Type:      corev1.ServiceTypeClusterIP,
+			ClusterIP: "10.0.0.1",
+			Ports:     []corev1.ServicePort{{Port: 80}, {Port: 443}},
+		},
+	}
+
+	ki.gatherService(context.Background(), svc, acc, cr)
+
+	acc.AssertContainsTaggedFields(t, serviceMeasurement, map[string]interface{}{
+		"created":        metav1.Time{}.Unix(),
+		"generation":     int64(0),
+		"port_count":     2,
+		"endpoint_count": 0,
+	}, map[string]string{
+		"namespace":    "default",
+		"service_name": "api",
+		"type":         "ClusterIP",
+		"cluster_ip":   "10.0.0.1",
+	})
+}
+
+func TestGatherIngressReportsBackendCount(t *testing.T) {
+	ki := &KubernetesInventory{}
+	acc := &testutil.Accumulator{}
+	cr := fakeClusterRuntime(t)
+
+	ing := &networkingv1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "web"},
+		Spec: networkingv1.IngressSpec{
+			TLS: []networkingv1.IngressTLS{{SecretName: "web-tls"}},
+			Rules: []networkingv1.IngressRule{
+				{
+					Host: "example.com",
+					IngressRuleValue: networkingv1.IngressRuleValue{
+						HTTP: &networkingv1.HTTPIngressRuleValue{
+							Paths: []networkingv1.HTTPIngressPath{{Path: "/"}},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	ki.gatherIngress(context.Background(), ing, acc, cr)
+
+	acc.AssertContainsTaggedFields(t, ingressMeasurement, map[string]interface{}{
+		"backend_count": 1,
+		"tls_count":     1,
+	}, map[string]string{
+		"namespace":    "default",
+		"ingress_name": "web",
+		"host":         "example.com",
+	})
+}
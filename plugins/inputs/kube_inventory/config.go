@@ -0,0 +1,54 @@
+package kube_inventory
+
+import (
+	"fmt"
+	"os"
+
+	restclient "k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+// resolveConfig builds the rest.Config used to reach one cluster's apiserver.
+//
+// When url is set it is used together with the bearer token and TLS config,
+// as before. When url is blank, the plugin instead auto-detects its
+// environment: it loads kubeconfig when one is configured, and otherwise
+// falls back to the in-cluster service account so the plugin can run as a
+// DaemonSet without any explicit connection settings.
+func resolveConfig(cfg ClusterConfig) (*restclient.Config, error) {
+	if cfg.URL != "" {
+		return restConfigFromURL(cfg.URL, cfg.BearerTokenString, cfg.ClientConfig)
+	}
+
+	if cfg.Kubeconfig != "" {
+		return kubeconfigRestConfig(cfg.Kubeconfig, cfg.KubeconfigContext)
+	}
+
+	restCfg, err := restclient.InClusterConfig()
+	if err != nil {
+		return nil, fmt.Errorf("kube_inventory: no url or kubeconfig set and not running in-cluster: %v", err)
+	}
+
+	return restCfg, nil
+}
+
+// kubeconfigRestConfig loads a rest.Config from a kubeconfig file, optionally
+// selecting a non-default context.
+func kubeconfigRestConfig(path, context string) (*restclient.Config, error) {
+	if _, err := os.Stat(path); err != nil {
+		return nil, fmt.Errorf("kube_inventory: kubeconfig %q not found: %v", path, err)
+	}
+
+	loadingRules := &clientcmd.ClientConfigLoadingRules{ExplicitPath: path}
+	overrides := &clientcmd.ConfigOverrides{}
+	if context != "" {
+		overrides.CurrentContext = context
+	}
+
+	cfg, err := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(loadingRules, overrides).ClientConfig()
+	if err != nil {
+		return nil, fmt.Errorf("kube_inventory: error loading kubeconfig %q: %v", path, err)
+	}
+
+	return cfg, nil
+}
@@ -0,0 +1,48 @@
+package kube_inventory
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestResolveConfigUsesURLWhenSet(t *testing.T) {
+	cfg := ClusterConfig{URL: "https://example.com", BearerTokenString: "abc_123"}
+
+	restCfg, err := resolveConfig(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if restCfg.Host != "https://example.com" {
+		t.Fatalf("expected host to come from cfg.URL, got %q", restCfg.Host)
+	}
+	if restCfg.BearerToken != "abc_123" {
+		t.Fatalf("expected bearer token to come from cfg.BearerTokenString, got %q", restCfg.BearerToken)
+	}
+}
+
+func TestResolveConfigMissingKubeconfigFileErrors(t *testing.T) {
+	cfg := ClusterConfig{Kubeconfig: "/no/such/kubeconfig"}
+
+	_, err := resolveConfig(cfg)
+	if err == nil {
+		t.Fatal("expected an error for a missing kubeconfig file")
+	}
+	if !strings.Contains(err.Error(), "kubeconfig") {
+		t.Fatalf("expected error to mention the kubeconfig path, got %v", err)
+	}
+}
+
+func TestResolveConfigFallsBackToInClusterConfig(t *testing.T) {
+	cfg := ClusterConfig{}
+
+	// Outside a pod there's no service account mounted, so this should fail
+	// with a message pointing at the missing url/kubeconfig/in-cluster env
+	// rather than panicking or silently returning a zero-value config.
+	_, err := resolveConfig(cfg)
+	if err == nil {
+		t.Skip("running inside a cluster; in-cluster fallback succeeded as expected")
+	}
+	if !strings.Contains(err.Error(), "no url or kubeconfig set") {
+		t.Fatalf("expected error to explain the in-cluster fallback failed, got %v", err)
+	}
+}
@@ -0,0 +1,61 @@
+package kube_inventory
+
+import (
+	"context"
+
+	batchv1 "k8s.io/api/batch/v1"
+
+	"github.com/influxdata/telegraf"
+)
+
+func collectCronJobs(ctx context.Context, acc telegraf.Accumulator, ki *KubernetesInventory, cr *clusterRuntime) {
+	if ki.Watch {
+		selector, err := ki.cacheLabelSelector("cronjobs")
+		if err != nil {
+			acc.AddError(err)
+			return
+		}
+		list, err := cr.cronJobsLister().CronJobs(cr.client.namespace).List(selector)
+		if err != nil {
+			acc.AddError(err)
+			return
+		}
+		for _, j := range list {
+			ki.gatherCronJob(j, acc)
+		}
+		return
+	}
+
+	list, err := cr.client.getCronJobs(ctx, ki.listOptions("cronjobs"))
+	if err != nil {
+		acc.AddError(err)
+		return
+	}
+	for i := range list.Items {
+		ki.gatherCronJob(&list.Items[i], acc)
+	}
+}
+
+func (ki *KubernetesInventory) gatherCronJob(c *batchv1.CronJob, acc telegraf.Accumulator) {
+	fields := map[string]interface{}{
+		"created": c.CreationTimestamp.Unix(),
+		"active":  len(c.Status.Active),
+		"suspend": c.Spec.Suspend != nil && *c.Spec.Suspend,
+	}
+	if c.Status.LastScheduleTime != nil {
+		fields["last_schedule_time"] = c.Status.LastScheduleTime.Unix()
+	}
+	if c.Status.LastSuccessfulTime != nil {
+		fields["last_successful_time"] = c.Status.LastSuccessfulTime.Unix()
+	}
+
+	tags := map[string]string{
+		"namespace":    c.Namespace,
+		"cronjob_name": c.Name,
+		"schedule":     c.Spec.Schedule,
+	}
+
+	ki.addLabelTags(c.Labels, tags)
+
+	acc.AddFields(cronJobMeasurement, fields, tags)
+}
@@ -0,0 +1,60 @@
+package kube_inventory
+
+import (
+	"context"
+
+	appsv1 "k8s.io/api/apps/v1"
+
+	"github.com/influxdata/telegraf"
+)
+
+func collectDaemonSets(ctx context.Context, acc telegraf.Accumulator, ki *KubernetesInventory, cr *clusterRuntime) {
+	if ki.Watch {
+		selector, err := ki.cacheLabelSelector("daemonsets")
+		if err != nil {
+			acc.AddError(err)
+			return
+		}
+		list, err := cr.daemonSetsLister().DaemonSets(cr.client.namespace).List(selector)
+		if err != nil {
+			acc.AddError(err)
+			return
+		}
+		for _, d := range list {
+			ki.gatherDaemonSet(d, acc)
+		}
+		return
+	}
+
+	list, err := cr.client.getDaemonSets(ctx, ki.listOptions("daemonsets"))
+	if err != nil {
+		acc.AddError(err)
+		return
+	}
+	for i := range list.Items {
+		ki.gatherDaemonSet(&list.Items[i], acc)
+	}
+}
+
+func (ki *KubernetesInventory) gatherDaemonSet(d *appsv1.DaemonSet, acc telegraf.Accumulator) {
+	fields := map[string]interface{}{
+		"created":                  d.CreationTimestamp.Unix(),
+		"generation":               d.Generation,
+		"desired_number_scheduled": d.Status.DesiredNumberScheduled,
+		"current_number_scheduled": d.Status.CurrentNumberScheduled,
+		"number_available":         d.Status.NumberAvailable,
+		"number_misscheduled":      d.Status.NumberMisscheduled,
+		"number_ready":             d.Status.NumberReady,
+		"number_unavailable":       d.Status.NumberUnavailable,
+		"updated_number_scheduled": d.Status.UpdatedNumberScheduled,
+	}
+
+	tags := map[string]string{
+		"namespace":      d.Namespace,
+		"daemonset_name": d.Name,
+	}
+
+	ki.addLabelTags(d.Labels, tags)
+
+	acc.AddFields(daemonSetMeasurement, fields, tags)
+}
@@ -0,0 +1,58 @@
+package kube_inventory
+
+import (
+	"context"
+
+	appsv1 "k8s.io/api/apps/v1"
+
+	"github.com/influxdata/telegraf"
+)
+
+func collectDeployments(ctx context.Context, acc telegraf.Accumulator, ki *KubernetesInventory, cr *clusterRuntime) {
+	if ki.Watch {
+		selector, err := ki.cacheLabelSelector("deployments")
+		if err != nil {
+			acc.AddError(err)
+			return
+		}
+		list, err := cr.deploymentsLister().Deployments(cr.client.namespace).List(selector)
+		if err != nil {
+			acc.AddError(err)
+			return
+		}
+		for _, d := range list {
+			ki.gatherDeployment(d, acc)
+		}
+		return
+	}
+
+	list, err := cr.client.getDeployments(ctx, ki.listOptions("deployments"))
+	if err != nil {
+		acc.AddError(err)
+		return
+	}
+	for i := range list.Items {
+		ki.gatherDeployment(&list.Items[i], acc)
+	}
+}
+
+func (ki *KubernetesInventory) gatherDeployment(d *appsv1.Deployment, acc telegraf.Accumulator) {
+	fields := map[string]interface{}{
+		"created":              d.CreationTimestamp.Unix(),
+		"generation":           d.Generation,
+		"observed_generation":  d.Status.ObservedGeneration,
+		"replicas":             d.Status.Replicas,
+		"replicas_available":   d.Status.AvailableReplicas,
+		"replicas_unavailable": d.Status.UnavailableReplicas,
+		"replicas_updated":     d.Status.UpdatedReplicas,
+	}
+
+	tags := map[string]string{
+		"namespace":       d.Namespace,
+		"deployment_name": d.Name,
+	}
+
+	ki.addLabelTags(d.Labels, tags)
+
+	acc.AddFields(deploymentMeasurement, fields, tags)
+}
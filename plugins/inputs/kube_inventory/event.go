@@ -0,0 +1,65 @@
+package kube_inventory
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/influxdata/telegraf"
+)
+
+var kubernetesEventMeasurement = "kubernetes_event"
+
+// setupEventWatch registers handlers on the shared Event informer that
+// translate add/update/delete notifications into kubernetes_event metrics,
+// so alerts on things like pod restarts, OOM kills and failed scheduling can
+// be built directly off of them.
+func (ki *KubernetesInventory) setupEventWatch(cr *clusterRuntime, acc telegraf.Accumulator) error {
+	informer := cr.informerFactory.Core().V1().Events().Informer()
+
+	_, err := informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			ki.handleEvent(acc, obj, "add")
+		},
+		UpdateFunc: func(_, obj interface{}) {
+			ki.handleEvent(acc, obj, "update")
+		},
+		DeleteFunc: func(obj interface{}) {
+			ki.handleEvent(acc, obj, "delete")
+		},
+	})
+
+	return err
+}
+
+func (ki *KubernetesInventory) handleEvent(acc telegraf.Accumulator, obj interface{}, action string) {
+	event, ok := obj.(*corev1.Event)
+	if !ok {
+		if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+			event, ok = tombstone.Obj.(*corev1.Event)
+			if !ok {
+				return
+			}
+		} else {
+			return
+		}
+	}
+
+	tags := map[string]string{
+		"namespace":            event.Namespace,
+		"type":                 event.Type,
+		"reason":               event.Reason,
+		"involved_object_kind": event.InvolvedObject.Kind,
+		"involved_object_name": event.InvolvedObject.Name,
+		"source_component":     event.Source.Component,
+		"action":               action,
+	}
+
+	fields := map[string]interface{}{
+		"count":           event.Count,
+		"message":         event.Message,
+		"first_timestamp": event.FirstTimestamp.Unix(),
+		"last_timestamp":  event.LastTimestamp.Unix(),
+	}
+
+	acc.AddFields(kubernetesEventMeasurement, fields, tags)
+}
@@ -0,0 +1,93 @@
+package kube_inventory
+
+import (
+	"context"
+
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/influxdata/telegraf"
+)
+
+func collectHorizontalPodAutoscalers(ctx context.Context, acc telegraf.Accumulator, ki *KubernetesInventory, cr *clusterRuntime) {
+	if ki.Watch {
+		selector, err := ki.cacheLabelSelector("hpas")
+		if err != nil {
+			acc.AddError(err)
+			return
+		}
+		list, err := cr.horizontalPodAutoscalersLister().HorizontalPodAutoscalers(cr.client.namespace).List(selector)
+		if err != nil {
+			acc.AddError(err)
+			return
+		}
+		for _, hpa := range list {
+			ki.gatherHorizontalPodAutoscaler(hpa, acc)
+		}
+		return
+	}
+
+	list, err := cr.client.getHorizontalPodAutoscalers(ctx, ki.listOptions("hpas"))
+	if err != nil {
+		acc.AddError(err)
+		return
+	}
+	for i := range list.Items {
+		ki.gatherHorizontalPodAutoscaler(&list.Items[i], acc)
+	}
+}
+
+func (ki *KubernetesInventory) gatherHorizontalPodAutoscaler(hpa *autoscalingv2.HorizontalPodAutoscaler, acc telegraf.Accumulator) {
+	fields := map[string]interface{}{
+		"created":          hpa.CreationTimestamp.Unix(),
+		"min_replicas":     int32(1),
+		"max_replicas":     hpa.Spec.MaxReplicas,
+		"current_replicas": hpa.Status.CurrentReplicas,
+		"desired_replicas": hpa.Status.DesiredReplicas,
+	}
+	if hpa.Spec.MinReplicas != nil {
+		fields["min_replicas"] = *hpa.Spec.MinReplicas
+	}
+
+	tags := map[string]string{
+		"namespace": hpa.Namespace,
+		"hpa_name":  hpa.Name,
+		"ref_kind":  hpa.Spec.ScaleTargetRef.Kind,
+		"ref_name":  hpa.Spec.ScaleTargetRef.Name,
+	}
+
+	ki.addLabelTags(hpa.Labels, tags)
+
+	acc.AddFields(hpaMeasurement, fields, tags)
+
+	// targetUtilization holds what each resource metric is configured to
+	// scale against, so it can be reported next to the current snapshot
+	// below instead of leaving current vs desired replicas as the only clue
+	// to what the HPA is actually doing.
+	targetUtilization := map[corev1.ResourceName]int32{}
+	for _, metric := range hpa.Spec.Metrics {
+		if metric.Resource == nil || metric.Resource.Target.AverageUtilization == nil {
+			continue
+		}
+		targetUtilization[metric.Resource.Name] = *metric.Resource.Target.AverageUtilization
+	}
+
+	for _, metric := range hpa.Status.CurrentMetrics {
+		if metric.Resource == nil || metric.Resource.Current.AverageUtilization == nil {
+			continue
+		}
+
+		metricFields := map[string]interface{}{
+			"current_utilization": *metric.Resource.Current.AverageUtilization,
+		}
+		if target, ok := targetUtilization[metric.Resource.Name]; ok {
+			metricFields["target_utilization"] = target
+		}
+		metricTags := map[string]string{
+			"namespace": hpa.Namespace,
+			"hpa_name":  hpa.Name,
+			"resource":  string(metric.Resource.Name),
+		}
+		acc.AddFields(hpaMeasurement+"_metric", metricFields, metricTags)
+	}
+}
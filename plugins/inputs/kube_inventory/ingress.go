@@ -0,0 +1,135 @@
+package kube_inventory
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+
+	"github.com/influxdata/telegraf"
+)
+
+func collectIngresses(ctx context.Context, acc telegraf.Accumulator, ki *KubernetesInventory, cr *clusterRuntime) {
+	if ki.Watch {
+		selector, err := ki.cacheLabelSelector("ingresses")
+		if err != nil {
+			acc.AddError(err)
+			return
+		}
+		list, err := cr.ingressesLister().Ingresses(cr.client.namespace).List(selector)
+		if err != nil {
+			acc.AddError(err)
+			return
+		}
+		for _, ing := range list {
+			ki.gatherIngress(ctx, ing, acc, cr)
+		}
+		return
+	}
+
+	list, err := cr.client.getIngresses(ctx, ki.listOptions("ingresses"))
+	if err != nil {
+		acc.AddError(err)
+		return
+	}
+	for i := range list.Items {
+		ki.gatherIngress(ctx, &list.Items[i], acc, cr)
+	}
+}
+
+func (ki *KubernetesInventory) gatherIngress(ctx context.Context, ing *networkingv1.Ingress, acc telegraf.Accumulator, cr *clusterRuntime) {
+	backendCount := 0
+	for _, rule := range ing.Spec.Rules {
+		if rule.HTTP == nil {
+			continue
+		}
+		backendCount += len(rule.HTTP.Paths)
+	}
+
+	healthyIngresses := 0
+	for _, lbIngress := range ing.Status.LoadBalancer.Ingress {
+		if lbIngress.IP != "" || lbIngress.Hostname != "" {
+			healthyIngresses++
+		}
+	}
+
+	fields := map[string]interface{}{
+		"created":       ing.CreationTimestamp.Unix(),
+		"generation":    ing.Generation,
+		"backend_count": backendCount,
+		"tls_count":     len(ing.Spec.TLS),
+		"healthy_lbs":   healthyIngresses,
+	}
+	if expiry, ok := ki.earliestTLSExpiry(ctx, cr, ing); ok {
+		fields["tls_expiry"] = expiry
+	}
+
+	tags := map[string]string{
+		"namespace":    ing.Namespace,
+		"ingress_name": ing.Name,
+	}
+	if len(ing.Spec.Rules) > 0 {
+		tags["host"] = ing.Spec.Rules[0].Host
+	}
+
+	ki.addLabelTags(ing.Labels, tags)
+
+	acc.AddFields(ingressMeasurement, fields, tags)
+}
+
+// earliestTLSExpiry resolves the Secret backing each of ing's TLS entries and
+// returns the soonest certificate expiry among them as a unix timestamp, so
+// an expiring Ingress certificate can be alerted on before it lapses.
+// Secrets that are missing or don't parse as a certificate are skipped.
+func (ki *KubernetesInventory) earliestTLSExpiry(ctx context.Context, cr *clusterRuntime, ing *networkingv1.Ingress) (int64, bool) {
+	var earliest int64
+	found := false
+
+	for _, tls := range ing.Spec.TLS {
+		if tls.SecretName == "" {
+			continue
+		}
+
+		var secret *corev1.Secret
+		var err error
+		if ki.Watch {
+			secret, err = cr.secretsLister().Secrets(ing.Namespace).Get(tls.SecretName)
+		} else {
+			secret, err = cr.client.getSecret(ctx, ing.Namespace, tls.SecretName)
+		}
+		if err != nil {
+			continue
+		}
+
+		notAfter, ok := certificateNotAfter(secret.Data[corev1.TLSCertKey])
+		if !ok {
+			continue
+		}
+
+		if !found || notAfter.Unix() < earliest {
+			earliest = notAfter.Unix()
+			found = true
+		}
+	}
+
+	return earliest, found
+}
+
+// certificateNotAfter parses the leaf certificate out of a PEM-encoded
+// kubernetes.io/tls secret's tls.crt and returns its NotAfter time.
+func certificateNotAfter(certPEM []byte) (time.Time, bool) {
+	block, _ := pem.Decode(certPEM)
+	if block == nil {
+		return time.Time{}, false
+	}
+
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	return cert.NotAfter, true
+}
@@ -0,0 +1,69 @@
+package kube_inventory
+
+import (
+	"context"
+
+	batchv1 "k8s.io/api/batch/v1"
+
+	"github.com/influxdata/telegraf"
+)
+
+func collectJobs(ctx context.Context, acc telegraf.Accumulator, ki *KubernetesInventory, cr *clusterRuntime) {
+	if ki.Watch {
+		selector, err := ki.cacheLabelSelector("jobs")
+		if err != nil {
+			acc.AddError(err)
+			return
+		}
+		list, err := cr.jobsLister().Jobs(cr.client.namespace).List(selector)
+		if err != nil {
+			acc.AddError(err)
+			return
+		}
+		for _, j := range list {
+			ki.gatherJob(j, acc)
+		}
+		return
+	}
+
+	list, err := cr.client.getJobs(ctx, ki.listOptions("jobs"))
+	if err != nil {
+		acc.AddError(err)
+		return
+	}
+	for i := range list.Items {
+		ki.gatherJob(&list.Items[i], acc)
+	}
+}
+
+func (ki *KubernetesInventory) gatherJob(j *batchv1.Job, acc telegraf.Accumulator) {
+	fields := map[string]interface{}{
+		"created":     j.CreationTimestamp.Unix(),
+		"active":      j.Status.Active,
+		"succeeded":   j.Status.Succeeded,
+		"failed":      j.Status.Failed,
+		"completions": int32(1),
+		"parallelism": int32(1),
+	}
+	if j.Spec.Completions != nil {
+		fields["completions"] = *j.Spec.Completions
+	}
+	if j.Spec.Parallelism != nil {
+		fields["parallelism"] = *j.Spec.Parallelism
+	}
+	if j.Status.StartTime != nil {
+		fields["start_time"] = j.Status.StartTime.Unix()
+	}
+	if j.Status.CompletionTime != nil {
+		fields["completion_time"] = j.Status.CompletionTime.Unix()
+	}
+
+	tags := map[string]string{
+		"namespace": j.Namespace,
+		"job_name":  j.Name,
+	}
+
+	ki.addLabelTags(j.Labels, tags)
+
+	acc.AddFields(jobMeasurement, fields, tags)
+}
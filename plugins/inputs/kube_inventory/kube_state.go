@@ -2,16 +2,19 @@ package kube_inventory
 
 import (
 	"context"
-	"io/ioutil"
+	"fmt"
 	"strconv"
-	"strings"
 	"sync"
 	"time"
 
 	"github.com/influxdata/telegraf"
+	"github.com/influxdata/telegraf/filter"
 	"github.com/influxdata/telegraf/internal"
 	"github.com/influxdata/telegraf/internal/tls"
 	"github.com/influxdata/telegraf/plugins/inputs"
+
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/tools/cache"
 )
 
 // KubernetesInventory represents the config object for the plugin.
@@ -25,17 +28,83 @@ type KubernetesInventory struct {
 	ResourceInclude   []string          `toml:"resource_include"`
 	MaxConfigMapAge   internal.Duration `toml:"max_config_map_age"`
 
+	// Kubeconfig, when url is empty, is loaded to build the client instead
+	// of falling back to in-cluster service account discovery.
+	Kubeconfig        string `toml:"kubeconfig"`
+	KubeconfigContext string `toml:"kubeconfig_context"`
+
+	// ClusterName tags metrics gathered from the single-cluster form above.
+	// Ignored when Clusters is set.
+	ClusterName string `toml:"cluster_name"`
+
+	// Clusters lets a single plugin instance gather from a fleet of
+	// clusters concurrently; each entry is tagged with its cluster_name.
+	// When empty, the url/bearer_token/namespace/tls_*/cluster_name fields
+	// above describe the single cluster to gather from.
+	Clusters []ClusterConfig `toml:"clusters"`
+
+	// Watch, when true, starts shared informers for the enabled resources
+	// instead of polling the apiserver on every Gather, and additionally
+	// streams Kubernetes events as kubernetes_event metrics.
+	Watch        bool              `toml:"watch"`
+	ResyncPeriod internal.Duration `toml:"resync_period"`
+
+	// Selectors configures per-resource label/field selectors, keyed by the
+	// same names used in resource_include/resource_exclude.
+	Selectors map[string]Selector `toml:"selectors"`
+
+	// LabelInclude/LabelExclude control which object labels are copied onto
+	// emitted metrics as tags.
+	LabelInclude []string `toml:"label_include"`
+	LabelExclude []string `toml:"label_exclude"`
+
 	tls.ClientConfig
-	client *client
+	labelFilter filter.Filter
+
+	Log telegraf.Logger `toml:"-"`
+
+	clusters []*clusterRuntime
+	mu       sync.Mutex
 }
 
+// ensure the informer-based watch mode satisfies telegraf's service input
+// lifecycle (Start is called once at startup, Stop on shutdown).
+var _ telegraf.ServiceInput = &KubernetesInventory{}
+
 var sampleConfig = `
   ## URL for the kubelet
+  ## Leave blank to auto-detect the in-cluster service account, or set
+  ## kubeconfig below to read connection details from a kubeconfig file.
   url = "https://127.0.0.1"
 
+  ## Kubeconfig file to use instead of an explicit url. Only consulted when
+  ## url is blank.
+  # kubeconfig = "/path/to/kubeconfig"
+  ## Context to use from kubeconfig. Defaults to kubeconfig's current-context.
+  # kubeconfig_context = ""
+
   ## Namespace to use
   # namespace = "default"
 
+  ## Name this cluster's metrics are tagged with. Only used for the
+  ## single-cluster form above; ignored when clusters is set below.
+  # cluster_name = ""
+
+  ## Gather from a fleet of clusters instead of a single one. Each entry
+  ## takes the same url/bearer_token/namespace/tls_*/cluster_name settings
+  ## as the single-cluster form above and is gathered concurrently, with
+  ## every emitted metric tagged with its cluster_name. cluster_name should
+  ## be set on every entry: with more than one cluster configured, a blank
+  ## cluster_name falls back to that entry's url (or kubeconfig, if url is
+  ## blank too) so metrics from different clusters don't collapse into the
+  ## same series.
+  # [[inputs.kube_inventory.clusters]]
+  #   url = "https://cluster-a.example.com"
+  #   cluster_name = "cluster-a"
+  # [[inputs.kube_inventory.clusters]]
+  #   url = "https://cluster-b.example.com"
+  #   cluster_name = "cluster-b"
+
   ## Use bearer token for authorization. ('bearer_token' takes priority)
   # bearer_token = "/path/to/bearer/token"
   ## OR
@@ -47,19 +116,44 @@ var sampleConfig = `
   ## Optional Resources to exclude from gathering
   ## Leave them with blank with try to gather everything available.
   ## Values can be - "daemonsets", deployments", "nodes", "persistentvolumes",
-  ## "persistentvolumeclaims", "pods", "statefulsets"
+  ## "persistentvolumeclaims", "pods", "statefulsets", "services", "ingresses",
+  ## "jobs", "cronjobs", "hpas", "resourcequotas", "namespaces"
   # resource_exclude = [ "deployments", "nodes", "statefulsets" ]
 
   ## Optional Resources to include when gathering
   ## Overrides resource_exclude if both set.
   # resource_include = [ "deployments", "nodes", "statefulsets" ]
 
+  ## Optional label/field selectors to apply server-side per resource kind,
+  ## keyed by the same names used in resource_include/resource_exclude.
+  ## NOTE: field_selector only has an effect when watch = false. Informer
+  ## Listers can only filter by label, so once watch = true is set,
+  ## field_selector is ignored for that resource kind and a warning is logged.
+  # [inputs.kube_inventory.selectors.pods]
+  #   label_selector = "app in (api,web)"
+  #   field_selector = "status.phase=Running"
+
+  ## Glob lists of object labels to include/exclude as tags on emitted
+  ## metrics. No labels are included by default.
+  # label_include = []
+  # label_exclude = []
+
   ## Optional TLS Config
   # tls_ca = "/path/to/cafile"
   # tls_cert = "/path/to/certfile"
   # tls_key = "/path/to/keyfile"
   ## Use TLS but skip chain & host verification
   # insecure_skip_verify = false
+
+  ## Use watch-based collection instead of polling the apiserver every
+  ## interval. Shared informers are started once on plugin startup for every
+  ## enabled resource kind, and Gather reads from their local caches instead
+  ## of the apiserver. Kubernetes events are always streamed as they happen.
+  # watch = false
+
+  ## How often the informer caches are resynced with the apiserver when
+  ## watch = true.
+  # resync_period = "5m"
 `
 
 // SampleConfig returns a sample config
@@ -72,32 +166,144 @@ func (ki *KubernetesInventory) Description() string {
 	return "Read metrics from the Kubernetes api"
 }
 
-// Gather collects kubernetes metrics from a given URL.
-func (ki *KubernetesInventory) Gather(acc telegraf.Accumulator) (err error) {
-	if ki.client == nil {
-		if ki.client, err = ki.initClient(); err != nil {
+// Start implements telegraf.ServiceInput. When watch is enabled it starts a
+// shared informer factory per cluster, the event handlers that back
+// kubernetes_event, and the Listers Gather reads cachedResourceKinds from;
+// it is a no-op otherwise.
+func (ki *KubernetesInventory) Start(acc telegraf.Accumulator) error {
+	if !ki.Watch {
+		return nil
+	}
+
+	ki.mu.Lock()
+	defer ki.mu.Unlock()
+
+	if err := ki.ensureClusters(); err != nil {
+		return err
+	}
+
+	for _, cr := range ki.clusters {
+		if cr.stopCh != nil {
+			// already up: either Start already ran to completion for this
+			// cluster, or a prior call got this far before a later
+			// cluster's sync timed out. Either way, leave it running
+			// instead of restarting it.
+			continue
+		}
+
+		clusterAcc := &clusterAccumulator{Accumulator: acc, clusterName: cr.cfg.ClusterName}
+
+		stopCh := make(chan struct{})
+		informerFactory := informers.NewSharedInformerFactory(cr.client.Clientset, ki.ResyncPeriod.Duration)
+		cr.stopCh = stopCh
+		cr.informerFactory = informerFactory
+
+		if err := ki.setupEventWatch(cr, clusterAcc); err != nil {
+			close(stopCh)
+			cr.stopCh = nil
+			cr.informerFactory = nil
 			return err
 		}
+
+		syncFuncs := []cache.InformerSynced{informerFactory.Core().V1().Events().Informer().HasSynced}
+		for _, resource := range cachedResourceKinds {
+			if !ki.resourceEnabled(resource) {
+				continue
+			}
+			syncFuncs = append(syncFuncs, registerCacheInformer(cr, resource))
+		}
+		if ki.resourceEnabled("services") {
+			// gatherService resolves each Service's Endpoints to report
+			// endpoint_count, so the Endpoints informer needs to be synced
+			// too whenever services are gathered.
+			syncFuncs = append(syncFuncs, informerFactory.Core().V1().Endpoints().Informer().HasSynced)
+		}
+		if ki.resourceEnabled("ingresses") {
+			// gatherIngress resolves each TLS secret to report tls_expiry,
+			// so the Secrets informer needs to be synced too whenever
+			// ingresses are gathered.
+			syncFuncs = append(syncFuncs, informerFactory.Core().V1().Secrets().Informer().HasSynced)
+		}
+
+		informerFactory.Start(stopCh)
+		if !cache.WaitForCacheSync(stopCh, syncFuncs...) {
+			// Roll this cluster back to not-started so the next Gather
+			// retries just this one, rather than leaving it stuck with a
+			// non-nil stopCh that Start would otherwise skip forever.
+			close(stopCh)
+			cr.stopCh = nil
+			cr.informerFactory = nil
+			return fmt.Errorf("kube_inventory: timed out waiting for informer caches to sync for cluster %q", cr.cfg.ClusterName)
+		}
+	}
+
+	return nil
+}
+
+// Stop implements telegraf.ServiceInput, shutting down the informer factories
+// started by Start.
+func (ki *KubernetesInventory) Stop() {
+	ki.mu.Lock()
+	defer ki.mu.Unlock()
+
+	for _, cr := range ki.clusters {
+		if cr.stopCh != nil {
+			close(cr.stopCh)
+			cr.stopCh = nil
+		}
+	}
+}
+
+// Gather collects kubernetes metrics from every configured cluster.
+func (ki *KubernetesInventory) Gather(acc telegraf.Accumulator) error {
+	ki.mu.Lock()
+	err := ki.ensureClusters()
+	ki.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	if ki.Watch {
+		ki.mu.Lock()
+		allStarted := true
+		for _, cr := range ki.clusters {
+			if cr.stopCh == nil {
+				allStarted = false
+				break
+			}
+		}
+		ki.mu.Unlock()
+		if !allStarted {
+			// Start skips clusters that are already up, so this only
+			// retries the ones that failed to sync last time.
+			if err := ki.Start(acc); err != nil {
+				return err
+			}
+		}
 	}
 
 	wg := sync.WaitGroup{}
 	ctx := context.Background()
 
-	if len(ki.ResourceInclude) == 0 {
-		for _, f := range availableCollectors {
-			wg.Add(1)
-			go func(f func(ctx context.Context, acc telegraf.Accumulator, k *KubernetesInventory)) {
-				defer wg.Done()
-				f(ctx, acc, ki)
-			}(f)
-		}
-	} else {
-		for _, n := range ki.ResourceInclude {
-			wg.Add(1)
-			go func(f func(ctx context.Context, acc telegraf.Accumulator, k *KubernetesInventory)) {
-				defer wg.Done()
-				f(ctx, acc, ki)
-			}(availableCollectors[n])
+	for _, cr := range ki.clusters {
+		clusterAcc := &clusterAccumulator{Accumulator: acc, clusterName: cr.cfg.ClusterName}
+
+		if len(ki.ResourceInclude) == 0 {
+			for _, f := range availableCollectors {
+				wg.Add(1)
+				go func(f func(ctx context.Context, acc telegraf.Accumulator, ki *KubernetesInventory, cr *clusterRuntime), cr *clusterRuntime) {
+					defer wg.Done()
+					f(ctx, clusterAcc, ki, cr)
+				}(f, cr)
+			}
+		} else {
+			for _, n := range ki.ResourceInclude {
+				wg.Add(1)
+				go func(f func(ctx context.Context, acc telegraf.Accumulator, ki *KubernetesInventory, cr *clusterRuntime), cr *clusterRuntime) {
+					defer wg.Done()
+					f(ctx, clusterAcc, ki, cr)
+				}(availableCollectors[n], cr)
+			}
 		}
 	}
 
@@ -106,7 +312,7 @@ func (ki *KubernetesInventory) Gather(acc telegraf.Accumulator) (err error) {
 	return nil
 }
 
-var availableCollectors = map[string]func(ctx context.Context, acc telegraf.Accumulator, ki *KubernetesInventory){
+var availableCollectors = map[string]func(ctx context.Context, acc telegraf.Accumulator, ki *KubernetesInventory, cr *clusterRuntime){
 	"daemonsets":             collectDaemonSets,
 	"deployments":            collectDeployments,
 	"nodes":                  collectNodes,
@@ -114,24 +320,78 @@ var availableCollectors = map[string]func(ctx context.Context, acc telegraf.Accu
 	"persistentvolumeclaims": collectPersistentVolumeClaims,
 	"pods":                   collectPods,
 	"statefulsets":           collectStatefulSets,
+	"services":               collectServices,
+	"ingresses":              collectIngresses,
+	"jobs":                   collectJobs,
+	"cronjobs":               collectCronJobs,
+	"hpas":                   collectHorizontalPodAutoscalers,
+	"resourcequotas":         collectResourceQuotas,
+	"namespaces":             collectNamespaces,
+}
+
+// resourceEnabled reports whether the named resource kind should be gathered.
+// resource_include overrides resource_exclude when set, same as Gather's
+// fan-out above; Start needs its own check because availableCollectors only
+// reflects resource_exclude deletions, so relying on it alone would start
+// informers for every resource kind even when resource_include narrows that
+// down to a handful.
+func (ki *KubernetesInventory) resourceEnabled(resource string) bool {
+	if len(ki.ResourceInclude) > 0 {
+		for _, r := range ki.ResourceInclude {
+			if r == resource {
+				return true
+			}
+		}
+		return false
+	}
+
+	_, enabled := availableCollectors[resource]
+	return enabled
 }
 
-func (ki *KubernetesInventory) initClient() (*client, error) {
+// ensureClusters lazily builds the per-cluster runtime state on first use.
+// Callers must hold ki.mu.
+func (ki *KubernetesInventory) ensureClusters() error {
+	if len(ki.clusters) > 0 {
+		return nil
+	}
+
 	if len(ki.ResourceInclude) == 0 {
 		for i := range ki.ResourceExclude {
 			delete(availableCollectors, ki.ResourceExclude[i])
 		}
 	}
 
-	if ki.BearerToken != "" {
-		token, err := ioutil.ReadFile(ki.BearerToken)
+	labelFilter, err := filter.NewIncludeExcludeFilter(ki.LabelInclude, ki.LabelExclude)
+	if err != nil {
+		return err
+	}
+	ki.labelFilter = labelFilter
+
+	ki.warnIneffectiveFieldSelectors()
+
+	cfgs := ki.clusterConfigs()
+	ensureDistinctClusterNames(cfgs)
+
+	for _, cfg := range cfgs {
+		if err := cfg.resolveBearerToken(); err != nil {
+			return err
+		}
+
+		restCfg, err := resolveConfig(cfg)
+		if err != nil {
+			return err
+		}
+
+		c, err := newClient(cfg.Namespace, ki.ResponseTimeout.Duration, restCfg)
 		if err != nil {
-			return nil, err
+			return err
 		}
-		ki.BearerTokenString = strings.TrimSpace(string(token))
+
+		ki.clusters = append(ki.clusters, &clusterRuntime{cfg: cfg, client: c})
 	}
 
-	return newClient(ki.URL, ki.Namespace, ki.BearerTokenString, ki.ResponseTimeout.Duration, ki.ClientConfig)
+	return nil
 }
 
 func atoi(s string) int64 {
@@ -150,12 +410,20 @@ var (
 	persistentVolumeClaimMeasurement = "kubernetes_persistentvolumeclaim"
 	podContainerMeasurement          = "kubernetes_pod_container"
 	statefulSetMeasurement           = "kubernetes_statefulset"
+	serviceMeasurement               = "kubernetes_service"
+	ingressMeasurement               = "kubernetes_ingress"
+	jobMeasurement                   = "kubernetes_job"
+	cronJobMeasurement               = "kubernetes_cronjob"
+	hpaMeasurement                   = "kubernetes_hpa"
+	resourceQuotaMeasurement         = "kubernetes_resourcequota"
+	namespaceMeasurement             = "kubernetes_namespace"
 )
 
 func init() {
 	inputs.Add("kube_inventory", func() telegraf.Input {
 		return &KubernetesInventory{
 			ResponseTimeout: internal.Duration{Duration: time.Second * 5},
+			ResyncPeriod:    internal.Duration{Duration: time.Minute * 5},
 			Namespace:       "default",
 		}
 	})
@@ -0,0 +1,62 @@
+package kube_inventory
+
+import (
+	"testing"
+
+	"github.com/influxdata/telegraf/filter"
+)
+
+func TestAddLabelTagsDefaultExcludesAllLabels(t *testing.T) {
+	ki := &KubernetesInventory{}
+
+	tags := map[string]string{}
+	ki.addLabelTags(map[string]string{"app": "web", "team": "core"}, tags)
+
+	if len(tags) != 0 {
+		t.Fatalf("expected no labels to be copied by default, got %v", tags)
+	}
+}
+
+func TestAddLabelTagsRespectsLabelInclude(t *testing.T) {
+	ki := &KubernetesInventory{LabelInclude: []string{"app"}}
+
+	labelFilter, err := filter.NewIncludeExcludeFilter(ki.LabelInclude, ki.LabelExclude)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ki.labelFilter = labelFilter
+
+	tags := map[string]string{}
+	ki.addLabelTags(map[string]string{"app": "web", "team": "core"}, tags)
+
+	if tags["app"] != "web" {
+		t.Fatalf("expected app label to be copied, got %v", tags)
+	}
+	if _, ok := tags["team"]; ok {
+		t.Fatalf("expected team label to stay excluded, got %v", tags)
+	}
+}
+
+func TestStopWithoutStartIsSafe(t *testing.T) {
+	ki := &KubernetesInventory{Watch: true}
+	ki.clusters = []*clusterRuntime{{}}
+
+	ki.Stop()
+}
+
+func TestStopClearsStopCh(t *testing.T) {
+	ki := &KubernetesInventory{Watch: true}
+	stopCh := make(chan struct{})
+	ki.clusters = []*clusterRuntime{{stopCh: stopCh}}
+
+	ki.Stop()
+
+	select {
+	case <-stopCh:
+	default:
+		t.Fatal("expected Stop to close the cluster's stopCh")
+	}
+	if ki.clusters[0].stopCh != nil {
+		t.Fatal("expected Stop to clear the cluster's stopCh")
+	}
+}
@@ -0,0 +1,52 @@
+package kube_inventory
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/influxdata/telegraf"
+)
+
+func collectNamespaces(ctx context.Context, acc telegraf.Accumulator, ki *KubernetesInventory, cr *clusterRuntime) {
+	if ki.Watch {
+		selector, err := ki.cacheLabelSelector("namespaces")
+		if err != nil {
+			acc.AddError(err)
+			return
+		}
+		list, err := cr.namespacesLister().List(selector)
+		if err != nil {
+			acc.AddError(err)
+			return
+		}
+		for _, ns := range list {
+			ki.gatherNamespace(ns, acc)
+		}
+		return
+	}
+
+	list, err := cr.client.getNamespaces(ctx, ki.listOptions("namespaces"))
+	if err != nil {
+		acc.AddError(err)
+		return
+	}
+	for i := range list.Items {
+		ki.gatherNamespace(&list.Items[i], acc)
+	}
+}
+
+func (ki *KubernetesInventory) gatherNamespace(ns *corev1.Namespace, acc telegraf.Accumulator) {
+	fields := map[string]interface{}{
+		"created": ns.CreationTimestamp.Unix(),
+	}
+
+	tags := map[string]string{
+		"namespace_name": ns.Name,
+		"phase":          string(ns.Status.Phase),
+	}
+
+	ki.addLabelTags(ns.Labels, tags)
+
+	acc.AddFields(namespaceMeasurement, fields, tags)
+}
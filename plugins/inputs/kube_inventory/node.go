@@ -0,0 +1,76 @@
+package kube_inventory
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/influxdata/telegraf"
+)
+
+func collectNodes(ctx context.Context, acc telegraf.Accumulator, ki *KubernetesInventory, cr *clusterRuntime) {
+	if ki.Watch {
+		selector, err := ki.cacheLabelSelector("nodes")
+		if err != nil {
+			acc.AddError(err)
+			return
+		}
+		list, err := cr.nodesLister().List(selector)
+		if err != nil {
+			acc.AddError(err)
+			return
+		}
+		for _, n := range list {
+			ki.gatherNode(n, acc)
+		}
+		return
+	}
+
+	list, err := cr.client.getNodes(ctx, ki.listOptions("nodes"))
+	if err != nil {
+		acc.AddError(err)
+		return
+	}
+	for i := range list.Items {
+		ki.gatherNode(&list.Items[i], acc)
+	}
+}
+
+func (ki *KubernetesInventory) gatherNode(n *corev1.Node, acc telegraf.Accumulator) {
+	fields := map[string]interface{}{
+		"created":                  n.CreationTimestamp.Unix(),
+		"capacity_cpu_cores":       n.Status.Capacity.Cpu().AsApproximateFloat64(),
+		"capacity_memory_bytes":    n.Status.Capacity.Memory().AsApproximateFloat64(),
+		"capacity_pods":            n.Status.Capacity.Pods().Value(),
+		"allocatable_cpu_cores":    n.Status.Allocatable.Cpu().AsApproximateFloat64(),
+		"allocatable_memory_bytes": n.Status.Allocatable.Memory().AsApproximateFloat64(),
+		"allocatable_pods":         n.Status.Allocatable.Pods().Value(),
+	}
+
+	tags := map[string]string{
+		"node_name":     n.Name,
+		"unschedulable": boolTag(n.Spec.Unschedulable),
+	}
+
+	ki.addLabelTags(n.Labels, tags)
+
+	acc.AddFields(nodeMeasurement, fields, tags)
+
+	for _, cond := range n.Status.Conditions {
+		condFields := map[string]interface{}{
+			"status": string(cond.Status),
+		}
+		condTags := map[string]string{
+			"node_name": n.Name,
+			"condition": string(cond.Type),
+		}
+		acc.AddFields(nodeMeasurement+"_condition", condFields, condTags)
+	}
+}
+
+func boolTag(b bool) string {
+	if b {
+		return "true"
+	}
+	return "false"
+}
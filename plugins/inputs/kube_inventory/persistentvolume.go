@@ -0,0 +1,54 @@
+package kube_inventory
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/influxdata/telegraf"
+)
+
+func collectPersistentVolumes(ctx context.Context, acc telegraf.Accumulator, ki *KubernetesInventory, cr *clusterRuntime) {
+	if ki.Watch {
+		selector, err := ki.cacheLabelSelector("persistentvolumes")
+		if err != nil {
+			acc.AddError(err)
+			return
+		}
+		list, err := cr.persistentVolumesLister().List(selector)
+		if err != nil {
+			acc.AddError(err)
+			return
+		}
+		for _, pv := range list {
+			ki.gatherPersistentVolume(pv, acc)
+		}
+		return
+	}
+
+	list, err := cr.client.getPersistentVolumes(ctx, ki.listOptions("persistentvolumes"))
+	if err != nil {
+		acc.AddError(err)
+		return
+	}
+	for i := range list.Items {
+		ki.gatherPersistentVolume(&list.Items[i], acc)
+	}
+}
+
+func (ki *KubernetesInventory) gatherPersistentVolume(pv *corev1.PersistentVolume, acc telegraf.Accumulator) {
+	fields := map[string]interface{}{
+		"created":        pv.CreationTimestamp.Unix(),
+		"capacity_bytes": pv.Spec.Capacity.Storage().AsApproximateFloat64(),
+	}
+
+	tags := map[string]string{
+		"pv_name":      pv.Name,
+		"phase":        string(pv.Status.Phase),
+		"storageclass": pv.Spec.StorageClassName,
+	}
+
+	ki.addLabelTags(pv.Labels, tags)
+
+	acc.AddFields(persistentVolumeMeasurement, fields, tags)
+}
@@ -0,0 +1,63 @@
+package kube_inventory
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/influxdata/telegraf"
+)
+
+func collectPersistentVolumeClaims(ctx context.Context, acc telegraf.Accumulator, ki *KubernetesInventory, cr *clusterRuntime) {
+	if ki.Watch {
+		selector, err := ki.cacheLabelSelector("persistentvolumeclaims")
+		if err != nil {
+			acc.AddError(err)
+			return
+		}
+		list, err := cr.persistentVolumeClaimsLister().PersistentVolumeClaims(cr.client.namespace).List(selector)
+		if err != nil {
+			acc.AddError(err)
+			return
+		}
+		for _, pvc := range list {
+			ki.gatherPersistentVolumeClaim(pvc, acc)
+		}
+		return
+	}
+
+	list, err := cr.client.getPersistentVolumeClaims(ctx, ki.listOptions("persistentvolumeclaims"))
+	if err != nil {
+		acc.AddError(err)
+		return
+	}
+	for i := range list.Items {
+		ki.gatherPersistentVolumeClaim(&list.Items[i], acc)
+	}
+}
+
+func (ki *KubernetesInventory) gatherPersistentVolumeClaim(pvc *corev1.PersistentVolumeClaim, acc telegraf.Accumulator) {
+	fields := map[string]interface{}{
+		"created": pvc.CreationTimestamp.Unix(),
+	}
+	if storage, ok := pvc.Status.Capacity[corev1.ResourceStorage]; ok {
+		fields["capacity_bytes"] = storage.AsApproximateFloat64()
+	}
+
+	storageClass := ""
+	if pvc.Spec.StorageClassName != nil {
+		storageClass = *pvc.Spec.StorageClassName
+	}
+
+	tags := map[string]string{
+		"namespace":    pvc.Namespace,
+		"pvc_name":     pvc.Name,
+		"phase":        string(pvc.Status.Phase),
+		"storageclass": storageClass,
+		"volume_name":  pvc.Spec.VolumeName,
+	}
+
+	ki.addLabelTags(pvc.Labels, tags)
+
+	acc.AddFields(persistentVolumeClaimMeasurement, fields, tags)
+}
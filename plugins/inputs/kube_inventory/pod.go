@@ -0,0 +1,73 @@
+package kube_inventory
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/influxdata/telegraf"
+)
+
+func collectPods(ctx context.Context, acc telegraf.Accumulator, ki *KubernetesInventory, cr *clusterRuntime) {
+	if ki.Watch {
+		selector, err := ki.cacheLabelSelector("pods")
+		if err != nil {
+			acc.AddError(err)
+			return
+		}
+		list, err := cr.podsLister().Pods(cr.client.namespace).List(selector)
+		if err != nil {
+			acc.AddError(err)
+			return
+		}
+		for _, p := range list {
+			ki.gatherPod(p, acc)
+		}
+		return
+	}
+
+	list, err := cr.client.getPods(ctx, ki.listOptions("pods"))
+	if err != nil {
+		acc.AddError(err)
+		return
+	}
+	for i := range list.Items {
+		ki.gatherPod(&list.Items[i], acc)
+	}
+}
+
+func (ki *KubernetesInventory) gatherPod(p *corev1.Pod, acc telegraf.Accumulator) {
+	for _, cs := range p.Status.ContainerStatuses {
+		ki.gatherPodContainer(p, cs, acc)
+	}
+}
+
+func (ki *KubernetesInventory) gatherPodContainer(p *corev1.Pod, cs corev1.ContainerStatus, acc telegraf.Accumulator) {
+	state := "unknown"
+	switch {
+	case cs.State.Running != nil:
+		state = "running"
+	case cs.State.Terminated != nil:
+		state = "terminated"
+	case cs.State.Waiting != nil:
+		state = "waiting"
+	}
+
+	fields := map[string]interface{}{
+		"restarts_total": cs.RestartCount,
+		"ready":          cs.Ready,
+	}
+
+	tags := map[string]string{
+		"namespace":      p.Namespace,
+		"pod_name":       p.Name,
+		"container_name": cs.Name,
+		"node_name":      p.Spec.NodeName,
+		"phase":          string(p.Status.Phase),
+		"state":          state,
+	}
+
+	ki.addLabelTags(p.Labels, tags)
+
+	acc.AddFields(podContainerMeasurement, fields, tags)
+}
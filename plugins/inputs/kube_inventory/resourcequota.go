@@ -0,0 +1,58 @@
+package kube_inventory
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/influxdata/telegraf"
+)
+
+func collectResourceQuotas(ctx context.Context, acc telegraf.Accumulator, ki *KubernetesInventory, cr *clusterRuntime) {
+	if ki.Watch {
+		selector, err := ki.cacheLabelSelector("resourcequotas")
+		if err != nil {
+			acc.AddError(err)
+			return
+		}
+		list, err := cr.resourceQuotasLister().ResourceQuotas(cr.client.namespace).List(selector)
+		if err != nil {
+			acc.AddError(err)
+			return
+		}
+		for _, rq := range list {
+			ki.gatherResourceQuota(rq, acc)
+		}
+		return
+	}
+
+	list, err := cr.client.getResourceQuotas(ctx, ki.listOptions("resourcequotas"))
+	if err != nil {
+		acc.AddError(err)
+		return
+	}
+	for i := range list.Items {
+		ki.gatherResourceQuota(&list.Items[i], acc)
+	}
+}
+
+func (ki *KubernetesInventory) gatherResourceQuota(rq *corev1.ResourceQuota, acc telegraf.Accumulator) {
+	for name, hard := range rq.Status.Hard {
+		fields := map[string]interface{}{
+			"hard": hard.AsApproximateFloat64(),
+			"used": float64(0),
+		}
+		if used, ok := rq.Status.Used[name]; ok {
+			fields["used"] = used.AsApproximateFloat64()
+		}
+
+		tags := map[string]string{
+			"namespace":           rq.Namespace,
+			"resource_quota_name": rq.Name,
+			"resource":            string(name),
+		}
+		ki.addLabelTags(rq.Labels, tags)
+
+		acc.AddFields(resourceQuotaMeasurement, fields, tags)
+	}
+}
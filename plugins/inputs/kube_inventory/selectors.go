@@ -0,0 +1,77 @@
+package kube_inventory
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// Selector configures server-side label/field filtering for one resource
+// kind, e.g.:
+//
+//	[inputs.kube_inventory.selectors.pods]
+//	  label_selector = "app in (api,web)"
+//	  field_selector = "status.phase=Running"
+type Selector struct {
+	LabelSelector string `toml:"label_selector"`
+	FieldSelector string `toml:"field_selector"`
+}
+
+// listOptions builds the metav1.ListOptions for the named resource kind from
+// the configured selectors, so filtering happens server-side instead of
+// discarding unwanted objects after they've already been fetched.
+func (ki *KubernetesInventory) listOptions(resource string) metav1.ListOptions {
+	s, ok := ki.Selectors[resource]
+	if !ok {
+		return metav1.ListOptions{}
+	}
+
+	return metav1.ListOptions{
+		LabelSelector: s.LabelSelector,
+		FieldSelector: s.FieldSelector,
+	}
+}
+
+// cacheLabelSelector returns the labels.Selector to apply when reading the
+// named resource kind from an informer cache. Informer Listers can only
+// filter by label, so field_selector has no effect here and still requires
+// watch = false to be honored.
+func (ki *KubernetesInventory) cacheLabelSelector(resource string) (labels.Selector, error) {
+	s, ok := ki.Selectors[resource]
+	if !ok || s.LabelSelector == "" {
+		return labels.Everything(), nil
+	}
+
+	return labels.Parse(s.LabelSelector)
+}
+
+// warnIneffectiveFieldSelectors logs a warning for every configured resource
+// kind whose field_selector is silently ignored because watch = true, so
+// users who set both don't discover the gap only once their alerts stop
+// firing.
+func (ki *KubernetesInventory) warnIneffectiveFieldSelectors() {
+	if !ki.Watch || ki.Log == nil {
+		return
+	}
+
+	for resource, s := range ki.Selectors {
+		if s.FieldSelector == "" {
+			continue
+		}
+		ki.Log.Warnf("field_selector for %q has no effect while watch = true; informer Listers can only filter by label", resource)
+	}
+}
+
+// addLabelTags copies the object labels allowed by label_include/label_exclude
+// into tags. Unlike resource_include/resource_exclude, labels are opt-in: an
+// empty label_include copies nothing, rather than everything.
+func (ki *KubernetesInventory) addLabelTags(labels map[string]string, tags map[string]string) {
+	if len(ki.LabelInclude) == 0 {
+		return
+	}
+
+	for k, v := range labels {
+		if ki.labelFilter != nil && ki.labelFilter.Match(k) {
+			tags[k] = v
+		}
+	}
+}
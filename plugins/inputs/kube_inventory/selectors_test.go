@@ -0,0 +1,115 @@
+package kube_inventory
+
+import (
+	"fmt"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// testLogger is a minimal telegraf.Logger that records Warnf calls, so tests
+// can assert on warnIneffectiveFieldSelectors without a real plugin host.
+type testLogger struct {
+	warnings []string
+}
+
+func (l *testLogger) Error(args ...interface{})                 {}
+func (l *testLogger) Errorf(format string, args ...interface{}) {}
+func (l *testLogger) Debug(args ...interface{})                 {}
+func (l *testLogger) Debugf(format string, args ...interface{}) {}
+func (l *testLogger) Info(args ...interface{})                  {}
+func (l *testLogger) Infof(format string, args ...interface{})  {}
+func (l *testLogger) Warn(args ...interface{})                  {}
+func (l *testLogger) Warnf(format string, args ...interface{}) {
+	l.warnings = append(l.warnings, fmt.Sprintf(format, args...))
+}
+
+func TestListOptionsBuildsFromConfiguredSelector(t *testing.T) {
+	ki := &KubernetesInventory{
+		Selectors: map[string]Selector{
+			"pods": {LabelSelector: "app in (api,web)", FieldSelector: "status.phase=Running"},
+		},
+	}
+
+	opts := ki.listOptions("pods")
+
+	if opts.LabelSelector != "app in (api,web)" {
+		t.Fatalf("expected label_selector to be threaded through, got %q", opts.LabelSelector)
+	}
+	if opts.FieldSelector != "status.phase=Running" {
+		t.Fatalf("expected field_selector to be threaded through, got %q", opts.FieldSelector)
+	}
+}
+
+func TestListOptionsDefaultsToEmptyWhenUnconfigured(t *testing.T) {
+	ki := &KubernetesInventory{}
+
+	opts := ki.listOptions("pods")
+
+	if opts.LabelSelector != "" || opts.FieldSelector != "" {
+		t.Fatalf("expected no selectors for an unconfigured resource, got %+v", opts)
+	}
+}
+
+func TestCacheLabelSelectorParsesConfiguredLabelSelector(t *testing.T) {
+	ki := &KubernetesInventory{
+		Selectors: map[string]Selector{
+			"pods": {LabelSelector: "app=web"},
+		},
+	}
+
+	selector, err := ki.cacheLabelSelector("pods")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !selector.Matches(labels.Set{"app": "web"}) {
+		t.Fatal("expected selector to match app=web")
+	}
+	if selector.Matches(labels.Set{"app": "other"}) {
+		t.Fatal("expected selector not to match app=other")
+	}
+}
+
+func TestCacheLabelSelectorIgnoresFieldSelector(t *testing.T) {
+	// cacheLabelSelector only reads LabelSelector: field_selector has no
+	// effect against informer Listers, which is exactly what
+	// warnIneffectiveFieldSelectors exists to flag.
+	ki := &KubernetesInventory{
+		Selectors: map[string]Selector{
+			"pods": {FieldSelector: "status.phase=Running"},
+		},
+	}
+
+	selector, err := ki.cacheLabelSelector("pods")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !selector.Empty() {
+		t.Fatalf("expected field-selector-only config to fall back to labels.Everything(), got %v", selector)
+	}
+}
+
+func TestWarnIneffectiveFieldSelectorsOnlyWarnsUnderWatch(t *testing.T) {
+	ki := &KubernetesInventory{
+		Watch: false,
+		Selectors: map[string]Selector{
+			"pods": {FieldSelector: "status.phase=Running"},
+		},
+		Log: &testLogger{},
+	}
+
+	ki.warnIneffectiveFieldSelectors()
+
+	log := ki.Log.(*testLogger)
+	if len(log.warnings) != 0 {
+		t.Fatalf("expected no warnings while watch = false, got %v", log.warnings)
+	}
+
+	ki.Watch = true
+	ki.warnIneffectiveFieldSelectors()
+
+	if len(log.warnings) != 1 {
+		t.Fatalf("expected one warning for the pods field_selector under watch, got %v", log.warnings)
+	}
+}
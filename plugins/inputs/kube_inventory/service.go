@@ -0,0 +1,82 @@
+package kube_inventory
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/influxdata/telegraf"
+)
+
+func collectServices(ctx context.Context, acc telegraf.Accumulator, ki *KubernetesInventory, cr *clusterRuntime) {
+	if ki.Watch {
+		selector, err := ki.cacheLabelSelector("services")
+		if err != nil {
+			acc.AddError(err)
+			return
+		}
+		list, err := cr.servicesLister().Services(cr.client.namespace).List(selector)
+		if err != nil {
+			acc.AddError(err)
+			return
+		}
+		for _, s := range list {
+			ki.gatherService(ctx, s, acc, cr)
+		}
+		return
+	}
+
+	list, err := cr.client.getServices(ctx, ki.listOptions("services"))
+	if err != nil {
+		acc.AddError(err)
+		return
+	}
+	for i := range list.Items {
+		ki.gatherService(ctx, &list.Items[i], acc, cr)
+	}
+}
+
+func (ki *KubernetesInventory) gatherService(ctx context.Context, s *corev1.Service, acc telegraf.Accumulator, cr *clusterRuntime) {
+	fields := map[string]interface{}{
+		"created":        s.CreationTimestamp.Unix(),
+		"generation":     s.Generation,
+		"port_count":     len(s.Spec.Ports),
+		"endpoint_count": ki.readyEndpointCount(ctx, cr, s),
+	}
+
+	tags := map[string]string{
+		"namespace":    s.Namespace,
+		"service_name": s.Name,
+		"type":         string(s.Spec.Type),
+		"cluster_ip":   s.Spec.ClusterIP,
+	}
+
+	ki.addLabelTags(s.Labels, tags)
+
+	acc.AddFields(serviceMeasurement, fields, tags)
+}
+
+// readyEndpointCount returns the number of ready backend addresses behind s,
+// so a selector that matches zero Pods shows up as 0 rather than as the
+// near-always-zero count of externally-assigned IPs. A Service with no
+// Endpoints object (e.g. type ExternalName) reports 0.
+func (ki *KubernetesInventory) readyEndpointCount(ctx context.Context, cr *clusterRuntime, s *corev1.Service) int {
+	var endpoints *corev1.Endpoints
+	var err error
+
+	if ki.Watch {
+		endpoints, err = cr.endpointsLister().Endpoints(s.Namespace).Get(s.Name)
+	} else {
+		endpoints, err = cr.client.getEndpoints(ctx, s.Namespace, s.Name)
+	}
+	if err != nil {
+		return 0
+	}
+
+	count := 0
+	for _, subset := range endpoints.Subsets {
+		count += len(subset.Addresses)
+	}
+
+	return count
+}
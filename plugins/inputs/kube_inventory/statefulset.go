@@ -0,0 +1,61 @@
+package kube_inventory
+
+import (
+	"context"
+
+	appsv1 "k8s.io/api/apps/v1"
+
+	"github.com/influxdata/telegraf"
+)
+
+func collectStatefulSets(ctx context.Context, acc telegraf.Accumulator, ki *KubernetesInventory, cr *clusterRuntime) {
+	if ki.Watch {
+		selector, err := ki.cacheLabelSelector("statefulsets")
+		if err != nil {
+			acc.AddError(err)
+			return
+		}
+		list, err := cr.statefulSetsLister().StatefulSets(cr.client.namespace).List(selector)
+		if err != nil {
+			acc.AddError(err)
+			return
+		}
+		for _, s := range list {
+			ki.gatherStatefulSet(s, acc)
+		}
+		return
+	}
+
+	list, err := cr.client.getStatefulSets(ctx, ki.listOptions("statefulsets"))
+	if err != nil {
+		acc.AddError(err)
+		return
+	}
+	for i := range list.Items {
+		ki.gatherStatefulSet(&list.Items[i], acc)
+	}
+}
+
+func (ki *KubernetesInventory) gatherStatefulSet(s *appsv1.StatefulSet, acc telegraf.Accumulator) {
+	fields := map[string]interface{}{
+		"created":          s.CreationTimestamp.Unix(),
+		"generation":       s.Generation,
+		"spec_replicas":    int32(1),
+		"replicas":         s.Status.Replicas,
+		"replicas_current": s.Status.CurrentReplicas,
+		"replicas_ready":   s.Status.ReadyReplicas,
+		"replicas_updated": s.Status.UpdatedReplicas,
+	}
+	if s.Spec.Replicas != nil {
+		fields["spec_replicas"] = *s.Spec.Replicas
+	}
+
+	tags := map[string]string{
+		"namespace":        s.Namespace,
+		"statefulset_name": s.Name,
+	}
+
+	ki.addLabelTags(s.Labels, tags)
+
+	acc.AddFields(statefulSetMeasurement, fields, tags)
+}